@@ -65,21 +65,27 @@ type Decoder struct {
 	eof           bool
 	line          int
 	column        int
+	prevLine      int
+	prevColumn    int
+	streamOffset  int64
 	buffer        []byte
 	offset        int
 	preparedByte  byte
 	preparedValid bool
+	useNumber     bool
 }
 
 func (d *Decoder) next() (byte, error) {
 	if d.preparedValid {
 		d.preparedValid = false
+		d.advance(d.preparedByte)
 		return d.preparedByte, nil
 	}
 
 	if d.offset < len(d.buffer) {
 		b := d.buffer[d.offset]
 		d.offset++
+		d.advance(b)
 		return b, nil
 	}
 
@@ -90,15 +96,56 @@ func (d *Decoder) next() (byte, error) {
 		return 0, io.EOF
 	}
 
+	d.streamOffset += int64(len(d.buffer))
 	d.buffer = d.buffer[:n]
 	d.offset = 0
 
 	return d.next()
 }
 
+// advance records that b has just been consumed, updating the line/column
+// position and remembering the prior position so a following undo can
+// restore it
+func (d *Decoder) advance(b byte) {
+	d.prevLine, d.prevColumn = d.line, d.column
+	if b == '\n' {
+		d.line++
+		d.column = 1
+	} else {
+		d.column++
+	}
+}
+
 func (d *Decoder) undo(input byte) {
 	d.preparedByte = input
 	d.preparedValid = true
+	d.line, d.column = d.prevLine, d.prevColumn
+}
+
+// inputOffset returns the absolute number of bytes consumed from source,
+// not counting a byte currently held back by undo
+func (d *Decoder) inputOffset() int64 {
+	var unread int64
+	if d.preparedValid {
+		unread = 1
+	}
+	return d.streamOffset + int64(d.offset) - unread
+}
+
+// InputOffset returns the absolute byte offset of the decoder's current
+// position in the input stream
+func (d *Decoder) InputOffset() int64 {
+	return d.inputOffset()
+}
+
+// Line returns the 1-based line number of the decoder's current position
+func (d *Decoder) Line() int {
+	return d.line
+}
+
+// Column returns the 1-based column number of the decoder's current position
+func (d *Decoder) Column() int {
+	return d.column
 }
 
 func (d *Decoder) readToken(input byte) (Token, error) {
@@ -146,10 +193,115 @@ func (d *Decoder) readString() (Token, error) {
 	return string(all), nil
 }
 
+// numState is a state in the JSON number grammar:
+// -? (0 | [1-9][0-9]*) (\.[0-9]+)? ([eE][+-]?[0-9]+)?
+type numState int
+
+const (
+	numStart numState = iota
+	numNeg
+	numZero
+	numDigit
+	numDotFirst
+	numFrac
+	numExpSign
+	numExpFirst
+	numExp
+)
+
+// numComplete reports whether a number ending in state is a valid, complete
+// JSON number, i.e. it's safe to stop here and treat the next byte as
+// belonging to whatever follows
+func numComplete(state numState) bool {
+	switch state {
+	case numZero, numDigit, numFrac, numExp:
+		return true
+	}
+	return false
+}
+
+// numErr, returned as the next state, forces readNumber to report a
+// grammar error even from a state where the number would otherwise be
+// considered complete (e.g. a digit immediately following a leading zero)
+const numErr numState = -1
+
+// numStep advances state on seeing input, returning the next state, or
+// (0, false) if input cannot follow state (the number is either malformed,
+// if state is not yet complete, or simply over, if it is), or
+// (numErr, false) if input is always a grammar error regardless of state
+func numStep(state numState, input byte) (numState, bool) {
+	isDigit := input >= '0' && input <= '9'
+
+	switch state {
+	case numStart:
+		switch {
+		case input == '-':
+			return numNeg, true
+		case input == '0':
+			return numZero, true
+		case isDigit:
+			return numDigit, true
+		}
+	case numNeg:
+		switch {
+		case input == '0':
+			return numZero, true
+		case isDigit:
+			return numDigit, true
+		}
+	case numZero:
+		switch {
+		case input == '.':
+			return numDotFirst, true
+		case input == 'e' || input == 'E':
+			return numExpSign, true
+		case isDigit:
+			// a leading zero may not be followed by another digit
+			return numErr, false
+		}
+	case numDigit:
+		switch {
+		case isDigit:
+			return numDigit, true
+		case input == '.':
+			return numDotFirst, true
+		case input == 'e' || input == 'E':
+			return numExpSign, true
+		}
+	case numFrac:
+		switch {
+		case isDigit:
+			return numFrac, true
+		case input == 'e' || input == 'E':
+			return numExpSign, true
+		}
+	case numExp:
+		if isDigit {
+			return numExp, true
+		}
+	case numDotFirst:
+		if isDigit {
+			return numFrac, true
+		}
+	case numExpSign:
+		switch {
+		case input == '+' || input == '-':
+			return numExpFirst, true
+		case isDigit:
+			return numExp, true
+		}
+	case numExpFirst:
+		if isDigit {
+			return numExp, true
+		}
+	}
+
+	return 0, false
+}
+
 func (d *Decoder) readNumber() (Token, error) {
-	buffer := make([]byte, 64)
-	offset := 0
-	isFloat := false
+	var buffer []byte
+	state := numStart
 
 	for {
 		input, err := d.next()
@@ -159,30 +311,36 @@ func (d *Decoder) readNumber() (Token, error) {
 			break
 		}
 
-		if input == '.' || input == 'e' || input == 'E' {
-			isFloat = true
-		} else if (input < '0' || input > '9') && input != '-' {
-			d.undo(input)
-			break
+		next, ok := numStep(state, input)
+		if !ok {
+			if next != numErr && numComplete(state) {
+				d.undo(input)
+				break
+			}
+			return nil, d.err(fmt.Errorf("bad input byte 0x%02x while reading number", input))
 		}
 
-		if offset == len(buffer) {
-			return nil, d.err(errors.New("number is too long"))
-		}
+		state = next
+		buffer = append(buffer, input)
+	}
+
+	if !numComplete(state) {
+		return nil, d.err(errors.New("unexpected EOF while reading number"))
+	}
 
-		buffer[offset] = input
-		offset++
+	if d.useNumber {
+		return Number(buffer), nil
 	}
 
-	if isFloat {
-		f, err := strconv.ParseFloat(string(buffer[:offset]), 64)
+	if state == numFrac || state == numExp {
+		f, err := strconv.ParseFloat(string(buffer), 64)
 		if err != nil {
 			return nil, d.err(fmt.Errorf("failed to scan float: %s", err))
 		}
 		return f, nil
 	}
 
-	i, err := strconv.ParseInt(string(buffer[:offset]), 10, 64)
+	i, err := strconv.ParseInt(string(buffer), 10, 64)
 	if err != nil {
 		return nil, d.err(fmt.Errorf("failed to scan int: %s", err))
 	}
@@ -198,13 +356,6 @@ func (d *Decoder) skipWhitespace() (byte, error) {
 			return 0, err
 		}
 
-		if input == '\n' {
-			d.line++
-			d.column = 1
-		} else {
-			d.column++
-		}
-
 		if input != ' ' && input != '\r' && input != '\n' &&
 			input != '\t' && input != ',' && input != ':' {
 			return input, nil
@@ -212,8 +363,26 @@ func (d *Decoder) skipWhitespace() (byte, error) {
 	}
 }
 
+// SyntaxError reports a malformed token together with where it occurred
+// in the input stream
+type SyntaxError struct {
+	Offset int64
+	Line   int
+	Column int
+	Msg    string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("scan error on line %d at column %d: %s", e.Line, e.Column, e.Msg)
+}
+
 func (d *Decoder) err(e error) error {
-	return fmt.Errorf("scan error on line %d at column %d: %s", d.line, d.column, e)
+	return &SyntaxError{
+		Offset: d.inputOffset(),
+		Line:   d.line,
+		Column: d.column,
+		Msg:    e.Error(),
+	}
 }
 
 type stringReader struct {
@@ -25,7 +25,7 @@ func TestEmpty(t *testing.T) {
 
 func TestBad(t *testing.T) {
 	d := NewDecoder(bytes.NewBufferString("\x00"))
-	requireError(t, d)
+	requireSyntaxError(t, d, 1, 1, 2)
 }
 
 func TestBasic(t *testing.T) {
@@ -69,10 +69,10 @@ func TestNumber(t *testing.T) {
 	requireEOF(t, d)
 
 	d = NewDecoder(bytes.NewBufferString("4.."))
-	requireError(t, d)
+	requireSyntaxError(t, d, 3, 1, 4)
 
 	d = NewDecoder(bytes.NewBufferString("9223372036854775808")) // too large for int64
-	requireError(t, d)
+	requireSyntaxError(t, d, 19, 1, 20)
 
 	d = NewDecoder(bytes.NewBufferString(strings.Repeat("9", 100))) // too large for buffer
 	requireError(t, d)
@@ -162,6 +162,28 @@ func TestString(t *testing.T) {
 	requireEOF(t, d)
 }
 
+func TestPosition(t *testing.T) {
+	d := NewDecoder(bytes.NewBufferString("[1,\n  2]"))
+
+	requireToken(t, d, Delim('['))
+	require.EqualValues(t, 1, d.InputOffset())
+	require.Equal(t, 1, d.Line())
+	require.Equal(t, 2, d.Column())
+
+	requireToken(t, d, int64(1))
+	require.EqualValues(t, 2, d.InputOffset())
+	require.Equal(t, 1, d.Line())
+	require.Equal(t, 3, d.Column())
+
+	requireToken(t, d, int64(2))
+	require.EqualValues(t, 7, d.InputOffset())
+	require.Equal(t, 2, d.Line())
+	require.Equal(t, 4, d.Column())
+
+	requireToken(t, d, Delim(']'))
+	require.EqualValues(t, 8, d.InputOffset())
+}
+
 func TestRefill(t *testing.T) {
 	d := NewDecoder(bytes.NewBuffer(append(bytes.Repeat([]byte{' '}, bufferSize), []byte("null")...)))
 	requireToken(t, d, nil)
@@ -205,3 +227,13 @@ func requireError(t *testing.T, d *Decoder) {
 	require.Error(t, err)
 	require.NotEqual(t, io.EOF, err)
 }
+
+func requireSyntaxError(t *testing.T, d *Decoder, offset int64, line, column int) *SyntaxError {
+	_, err := d.Token()
+	synErr, ok := err.(*SyntaxError)
+	require.True(t, ok, "expected *SyntaxError, got %T: %v", err, err)
+	require.Equal(t, offset, synErr.Offset)
+	require.Equal(t, line, synErr.Line)
+	require.Equal(t, column, synErr.Column)
+	return synErr
+}
@@ -0,0 +1,279 @@
+package jsonstream
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+)
+
+// ErrMismatchedDelim occurs when a closing Delim doesn't match the currently open container
+var ErrMismatchedDelim = errors.New("closing delimiter does not match open container")
+
+// ErrUnsupportedValue occurs when asked to write a float64 that has no JSON
+// representation, such as NaN or an infinity
+var ErrUnsupportedValue = errors.New("value has no JSON representation")
+
+// NewEncoder creates a new encoder writing to w
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: w}
+}
+
+// Encoder writes a stream of JSON tokens, inserting the structural
+// characters (',' and ':') a caller of Decoder would otherwise have to
+// skip over themselves
+type Encoder struct {
+	w     io.Writer
+	stack []encFrame
+}
+
+// encFrame tracks the state of one open object or array
+type encFrame struct {
+	isObject bool
+	count    int
+}
+
+// WriteToken writes a single token, dispatching on its concrete type
+func (e *Encoder) WriteToken(token Token) error {
+	switch t := token.(type) {
+	case Delim:
+		return e.WriteDelim(t)
+	case string:
+		return e.WriteString(t)
+	case bool:
+		return e.WriteBool(t)
+	case nil:
+		return e.WriteNull()
+	case int64, float64, Number:
+		return e.WriteNumber(t)
+	default:
+		return fmt.Errorf("unsupported token type %T", token)
+	}
+}
+
+// WriteDelim writes one of '{', '}', '[' or ']', pushing or popping the
+// corresponding container frame
+func (e *Encoder) WriteDelim(d Delim) error {
+	switch d {
+	case '{', '[':
+		if err := e.prefix(); err != nil {
+			return err
+		}
+		if _, err := e.w.Write([]byte{byte(d)}); err != nil {
+			return err
+		}
+		e.afterWrite()
+		e.stack = append(e.stack, encFrame{isObject: d == '{'})
+		return nil
+	case '}', ']':
+		if len(e.stack) == 0 || e.stack[len(e.stack)-1].isObject != (d == '}') {
+			return ErrMismatchedDelim
+		}
+		e.stack = e.stack[:len(e.stack)-1]
+		_, err := e.w.Write([]byte{byte(d)})
+		return err
+	default:
+		return fmt.Errorf("not a delimiter: %q", rune(d))
+	}
+}
+
+// WriteString writes s as a JSON string
+func (e *Encoder) WriteString(s string) error {
+	if err := e.prefix(); err != nil {
+		return err
+	}
+	if err := writeQuoted(e.w, s); err != nil {
+		return err
+	}
+	e.afterWrite()
+	return nil
+}
+
+// WriteNumber writes n, which must be an int64, a float64 or a Number.
+// A float64 that is NaN or infinite has no JSON representation and is
+// rejected with ErrUnsupportedValue before anything is written
+func (e *Encoder) WriteNumber(n interface{}) error {
+	var s string
+	switch v := n.(type) {
+	case int64:
+		s = strconv.FormatInt(v, 10)
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return fmt.Errorf("%w: %v", ErrUnsupportedValue, v)
+		}
+		s = strconv.FormatFloat(v, 'g', -1, 64)
+	case Number:
+		s = string(v)
+	default:
+		return fmt.Errorf("not a number: %T", n)
+	}
+
+	if err := e.prefix(); err != nil {
+		return err
+	}
+
+	if _, err := io.WriteString(e.w, s); err != nil {
+		return err
+	}
+	e.afterWrite()
+	return nil
+}
+
+// WriteBool writes b as the JSON literal true or false
+func (e *Encoder) WriteBool(b bool) error {
+	if err := e.prefix(); err != nil {
+		return err
+	}
+	s := "false"
+	if b {
+		s = "true"
+	}
+	if _, err := io.WriteString(e.w, s); err != nil {
+		return err
+	}
+	e.afterWrite()
+	return nil
+}
+
+// WriteNull writes the JSON literal null
+func (e *Encoder) WriteNull() error {
+	if err := e.prefix(); err != nil {
+		return err
+	}
+	if _, err := io.WriteString(e.w, "null"); err != nil {
+		return err
+	}
+	e.afterWrite()
+	return nil
+}
+
+// StringWriter returns an io.WriteCloser that streams a JSON string value
+// out byte by byte, escaping as it goes; Close must be called to write the
+// closing quote
+func (e *Encoder) StringWriter() (io.WriteCloser, error) {
+	if err := e.prefix(); err != nil {
+		return nil, err
+	}
+	if _, err := io.WriteString(e.w, `"`); err != nil {
+		return nil, err
+	}
+
+	return &stringWriter{encoder: e}, nil
+}
+
+// prefix writes the separator ( ',' or ':' ) needed before the next token,
+// if the current container requires one
+func (e *Encoder) prefix() error {
+	if len(e.stack) == 0 {
+		return nil
+	}
+
+	top := &e.stack[len(e.stack)-1]
+	if top.count == 0 {
+		return nil
+	}
+
+	sep := byte(',')
+	if top.isObject && top.count%2 == 1 {
+		sep = ':'
+	}
+
+	_, err := e.w.Write([]byte{sep})
+	return err
+}
+
+// afterWrite records that a token was written into the currently open container
+func (e *Encoder) afterWrite() {
+	if len(e.stack) == 0 {
+		return
+	}
+	e.stack[len(e.stack)-1].count++
+}
+
+// writeQuoted writes s to w as a quoted, escaped JSON string
+func writeQuoted(w io.Writer, s string) error {
+	if _, err := io.WriteString(w, `"`); err != nil {
+		return err
+	}
+	if err := writeEscaped(w, []byte(s)); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, `"`)
+	return err
+}
+
+// writeEscaped writes b to w, applying JSON string escaping
+func writeEscaped(w io.Writer, b []byte) error {
+	for _, c := range b {
+		switch c {
+		case '"':
+			_, err := io.WriteString(w, `\"`)
+			if err != nil {
+				return err
+			}
+		case '\\':
+			if _, err := io.WriteString(w, `\\`); err != nil {
+				return err
+			}
+		case '\b':
+			if _, err := io.WriteString(w, `\b`); err != nil {
+				return err
+			}
+		case '\f':
+			if _, err := io.WriteString(w, `\f`); err != nil {
+				return err
+			}
+		case '\n':
+			if _, err := io.WriteString(w, `\n`); err != nil {
+				return err
+			}
+		case '\r':
+			if _, err := io.WriteString(w, `\r`); err != nil {
+				return err
+			}
+		case '\t':
+			if _, err := io.WriteString(w, `\t`); err != nil {
+				return err
+			}
+		default:
+			if c < 0x20 {
+				if _, err := fmt.Fprintf(w, `\u%04x`, c); err != nil {
+					return err
+				}
+				continue
+			}
+			if _, err := w.Write([]byte{c}); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// stringWriter streams raw bytes of a string value out, escaping as they arrive
+type stringWriter struct {
+	encoder *Encoder
+	closed  bool
+}
+
+func (s *stringWriter) Write(p []byte) (int, error) {
+	if err := writeEscaped(s.encoder.w, p); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (s *stringWriter) Close() error {
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+
+	if _, err := io.WriteString(s.encoder.w, `"`); err != nil {
+		return err
+	}
+	s.encoder.afterWrite()
+	return nil
+}
@@ -0,0 +1,124 @@
+package jsonstream
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeBasic(t *testing.T) {
+	var buf bytes.Buffer
+	e := NewEncoder(&buf)
+
+	require.NoError(t, e.WriteDelim(Delim('[')))
+	require.NoError(t, e.WriteNumber(int64(11)))
+	require.NoError(t, e.WriteNumber(2.2))
+	require.NoError(t, e.WriteString("bar"))
+	require.NoError(t, e.WriteBool(true))
+	require.NoError(t, e.WriteNull())
+	require.NoError(t, e.WriteDelim(Delim(']')))
+
+	d := NewDecoder(bytes.NewReader(buf.Bytes()))
+	requireToken(t, d, Delim('['))
+	requireToken(t, d, int64(11))
+	requireToken(t, d, 2.2)
+	requireToken(t, d, "bar")
+	requireToken(t, d, true)
+	requireToken(t, d, nil)
+	requireToken(t, d, Delim(']'))
+	requireEOF(t, d)
+}
+
+func TestEncodeObject(t *testing.T) {
+	var buf bytes.Buffer
+	e := NewEncoder(&buf)
+
+	require.NoError(t, e.WriteDelim(Delim('{')))
+	require.NoError(t, e.WriteString("a"))
+	require.NoError(t, e.WriteNumber(int64(1)))
+	require.NoError(t, e.WriteString("b"))
+	require.NoError(t, e.WriteDelim(Delim('[')))
+	require.NoError(t, e.WriteString("x"))
+	require.NoError(t, e.WriteString("y"))
+	require.NoError(t, e.WriteDelim(Delim(']')))
+	require.NoError(t, e.WriteDelim(Delim('}')))
+
+	d := NewDecoder(bytes.NewReader(buf.Bytes()))
+	requireToken(t, d, Delim('{'))
+	requireToken(t, d, "a")
+	requireToken(t, d, int64(1))
+	requireToken(t, d, "b")
+	requireToken(t, d, Delim('['))
+	requireToken(t, d, "x")
+	requireToken(t, d, "y")
+	requireToken(t, d, Delim(']'))
+	requireToken(t, d, Delim('}'))
+	requireEOF(t, d)
+}
+
+func TestEncodeStringWriter(t *testing.T) {
+	var buf bytes.Buffer
+	e := NewEncoder(&buf)
+
+	require.NoError(t, e.WriteDelim(Delim('[')))
+	w, err := e.StringWriter()
+	require.NoError(t, err)
+	_, err = io.WriteString(w, "hello \"world\"\n")
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	require.NoError(t, e.WriteDelim(Delim(']')))
+
+	d := NewDecoder(bytes.NewReader(buf.Bytes()))
+	requireToken(t, d, Delim('['))
+	r, err := d.StringReader()
+	require.NoError(t, err)
+	data, err := ioutil.ReadAll(r)
+	require.NoError(t, err)
+	require.Equal(t, "hello \"world\"\n", string(data))
+	requireToken(t, d, Delim(']'))
+	requireEOF(t, d)
+}
+
+func TestEncodeNonFiniteFloat(t *testing.T) {
+	var buf bytes.Buffer
+	e := NewEncoder(&buf)
+
+	require.NoError(t, e.WriteDelim(Delim('[')))
+	require.ErrorIs(t, e.WriteNumber(math.NaN()), ErrUnsupportedValue)
+	require.ErrorIs(t, e.WriteNumber(math.Inf(1)), ErrUnsupportedValue)
+	require.ErrorIs(t, e.WriteNumber(math.Inf(-1)), ErrUnsupportedValue)
+
+	// nothing should have been written for the rejected values, so a
+	// valid element can still follow without a stray separator
+	require.NoError(t, e.WriteNumber(int64(1)))
+	require.NoError(t, e.WriteDelim(Delim(']')))
+	require.Equal(t, "[1]", buf.String())
+}
+
+func TestEncodeMismatchedDelim(t *testing.T) {
+	var buf bytes.Buffer
+	e := NewEncoder(&buf)
+
+	require.NoError(t, e.WriteDelim(Delim('[')))
+	require.Equal(t, ErrMismatchedDelim, e.WriteDelim(Delim('}')))
+}
+
+func TestEncodeToken(t *testing.T) {
+	var buf bytes.Buffer
+	e := NewEncoder(&buf)
+
+	tokens := []Token{Delim('{'), "k", int64(42), Delim('}')}
+	for _, tok := range tokens {
+		require.NoError(t, e.WriteToken(tok))
+	}
+
+	d := NewDecoder(bytes.NewReader(buf.Bytes()))
+	for _, tok := range tokens {
+		requireToken(t, d, tok)
+	}
+	requireEOF(t, d)
+}
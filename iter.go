@@ -0,0 +1,163 @@
+package jsonstream
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ObjIter begins iterating the object that starts at the current position
+// in the stream, consuming its opening '{'
+func (d *Decoder) ObjIter() (*ObjectIterator, error) {
+	tok, err := d.Token()
+	if err != nil {
+		return nil, err
+	}
+	if tok != Delim('{') {
+		return nil, d.err(fmt.Errorf("expected '{' to start object iteration, got %v", tok))
+	}
+
+	return &ObjectIterator{d: d}, nil
+}
+
+// ObjectIterator walks the key/value pairs of a JSON object one at a time.
+// For each pair reported by Next, the caller reads the key via Key or
+// KeyReader and then consumes the value itself, e.g. with Token,
+// StringReader or Raw
+type ObjectIterator struct {
+	d       *Decoder
+	key     string
+	keyRead bool
+	err     error
+	done    bool
+}
+
+// Next advances to the next key/value pair, returning false at the end of
+// the object or on error
+func (it *ObjectIterator) Next() bool {
+	if it.err != nil || it.done {
+		return false
+	}
+
+	input, err := it.d.skipWhitespace()
+	if err != nil {
+		it.err = err
+		return false
+	}
+
+	if input == '}' {
+		it.done = true
+		return false
+	}
+
+	if input != '"' {
+		it.err = it.d.err(fmt.Errorf("expected '\"' or '}' while reading object key, got 0x%02x", input))
+		return false
+	}
+
+	it.d.undo(input)
+	it.keyRead = false
+	return true
+}
+
+// Key returns the current pair's key as a string
+func (it *ObjectIterator) Key() string {
+	if it.keyRead {
+		return it.key
+	}
+
+	tok, err := it.d.Token()
+	if err != nil {
+		it.err = err
+		return ""
+	}
+
+	s, ok := tok.(string)
+	if !ok {
+		it.err = it.d.err(errors.New("object key is not a string"))
+		return ""
+	}
+
+	it.key = s
+	it.keyRead = true
+	return it.key
+}
+
+// KeyReader streams the current pair's key without buffering it, mirroring
+// Decoder.StringReader
+func (it *ObjectIterator) KeyReader() (io.Reader, error) {
+	if it.keyRead {
+		return strings.NewReader(it.key), nil
+	}
+
+	r, err := it.d.StringReader()
+	if err != nil {
+		it.err = err
+		return nil, err
+	}
+
+	it.keyRead = true
+	it.key = ""
+	return r, nil
+}
+
+// Err returns the first error encountered during iteration, if any
+func (it *ObjectIterator) Err() error {
+	return it.err
+}
+
+// ArrIter begins iterating the array that starts at the current position
+// in the stream, consuming its opening '['
+func (d *Decoder) ArrIter() (*ArrayIterator, error) {
+	tok, err := d.Token()
+	if err != nil {
+		return nil, err
+	}
+	if tok != Delim('[') {
+		return nil, d.err(fmt.Errorf("expected '[' to start array iteration, got %v", tok))
+	}
+
+	return &ArrayIterator{d: d}, nil
+}
+
+// ArrayIterator walks the elements of a JSON array one at a time. For each
+// element reported by Next, the caller consumes it with Token,
+// StringReader or Raw
+type ArrayIterator struct {
+	d    *Decoder
+	err  error
+	done bool
+}
+
+// Next advances to the next element, returning false at the end of the
+// array or on error
+func (it *ArrayIterator) Next() bool {
+	if it.err != nil || it.done {
+		return false
+	}
+
+	input, err := it.d.skipWhitespace()
+	if err != nil {
+		it.err = err
+		return false
+	}
+
+	if input == ']' {
+		it.done = true
+		return false
+	}
+
+	if input == '}' {
+		it.err = it.d.err(errors.New("unexpected '}' while reading array element"))
+		return false
+	}
+
+	it.d.undo(input)
+	return true
+}
+
+// Err returns the first error encountered during iteration, if any
+func (it *ArrayIterator) Err() error {
+	return it.err
+}
@@ -0,0 +1,99 @@
+package jsonstream
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestObjIter(t *testing.T) {
+	d := NewDecoder(bytes.NewBufferString(`{"a": 1, "b": [1,2], "c": "x"}`))
+	it, err := d.ObjIter()
+	require.NoError(t, err)
+
+	var keys []string
+	for it.Next() {
+		keys = append(keys, it.Key())
+		require.NoError(t, d.Skip())
+	}
+	require.NoError(t, it.Err())
+	require.Equal(t, []string{"a", "b", "c"}, keys)
+	requireEOF(t, d)
+}
+
+func TestObjIterKeyReader(t *testing.T) {
+	d := NewDecoder(bytes.NewBufferString(`{"a": 1}`))
+	it, err := d.ObjIter()
+	require.NoError(t, err)
+
+	require.True(t, it.Next())
+	r, err := it.KeyReader()
+	require.NoError(t, err)
+	data, err := ioutil.ReadAll(r)
+	require.NoError(t, err)
+	require.Equal(t, "a", string(data))
+	requireToken(t, d, int64(1))
+
+	require.False(t, it.Next())
+	require.NoError(t, it.Err())
+}
+
+func TestObjIterEmpty(t *testing.T) {
+	d := NewDecoder(bytes.NewBufferString(`{}`))
+	it, err := d.ObjIter()
+	require.NoError(t, err)
+	require.False(t, it.Next())
+	require.NoError(t, it.Err())
+	requireEOF(t, d)
+}
+
+func TestObjIterBadKey(t *testing.T) {
+	d := NewDecoder(bytes.NewBufferString(`{1: 2}`))
+	it, err := d.ObjIter()
+	require.NoError(t, err)
+	require.False(t, it.Next())
+	require.Error(t, it.Err())
+}
+
+func TestObjIterNotAnObject(t *testing.T) {
+	d := NewDecoder(bytes.NewBufferString(`[1]`))
+	_, err := d.ObjIter()
+	require.Error(t, err)
+}
+
+func TestArrIter(t *testing.T) {
+	d := NewDecoder(bytes.NewBufferString(`[1, "x", [2,3]]`))
+	it, err := d.ArrIter()
+	require.NoError(t, err)
+
+	var values []Token
+	for it.Next() {
+		raw, err := d.Raw()
+		require.NoError(t, err)
+		values = append(values, string(raw))
+	}
+	require.NoError(t, it.Err())
+	require.Equal(t, []Token{"1", `"x"`, "[2,3]"}, values)
+	requireEOF(t, d)
+}
+
+func TestArrIterEmpty(t *testing.T) {
+	d := NewDecoder(bytes.NewBufferString(`[]`))
+	it, err := d.ArrIter()
+	require.NoError(t, err)
+	require.False(t, it.Next())
+	require.NoError(t, it.Err())
+	requireEOF(t, d)
+}
+
+func TestArrIterMismatchedDelim(t *testing.T) {
+	d := NewDecoder(bytes.NewBufferString(`[1}`))
+	it, err := d.ArrIter()
+	require.NoError(t, err)
+	require.True(t, it.Next())
+	require.NoError(t, d.Skip())
+	require.False(t, it.Next())
+	require.Error(t, it.Err())
+}
@@ -0,0 +1,31 @@
+package jsonstream
+
+import "strconv"
+
+// Number is the textual representation of a JSON number, returned by
+// Token/Decode in place of int64/float64 once UseNumber has been called.
+// It preserves precision and formatting (e.g. the trailing digits of a
+// high-precision decimal) that a 64-bit int64/float64 conversion would lose
+type Number string
+
+// Int64 parses n as a base-10 int64, as strconv.ParseInt would
+func (n Number) Int64() (int64, error) {
+	return strconv.ParseInt(string(n), 10, 64)
+}
+
+// Float64 parses n as a float64, as strconv.ParseFloat would
+func (n Number) Float64() (float64, error) {
+	return strconv.ParseFloat(string(n), 64)
+}
+
+func (n Number) String() string {
+	return string(n)
+}
+
+// UseNumber causes subsequent numeric tokens to be returned as a Number
+// instead of being eagerly parsed into int64/float64. This avoids
+// rejecting numbers that don't fit in 64 bits, and lets the caller defer
+// the choice of representation
+func (d *Decoder) UseNumber() {
+	d.useNumber = true
+}
@@ -0,0 +1,89 @@
+package jsonstream
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUseNumber(t *testing.T) {
+	d := NewDecoder(bytes.NewBufferString("9223372036854775808 3.14 -0.5e10"))
+	d.UseNumber()
+
+	requireToken(t, d, Number("9223372036854775808"))
+	requireToken(t, d, Number("3.14"))
+	requireToken(t, d, Number("-0.5e10"))
+	requireEOF(t, d)
+}
+
+func TestNumberInt64Float64(t *testing.T) {
+	n := Number("42")
+	i, err := n.Int64()
+	require.NoError(t, err)
+	require.Equal(t, int64(42), i)
+
+	f, err := n.Float64()
+	require.NoError(t, err)
+	require.Equal(t, 42.0, f)
+	require.Equal(t, "42", n.String())
+}
+
+func TestLongNumberWithoutCap(t *testing.T) {
+	d := NewDecoder(bytes.NewBufferString(strings.Repeat("9", 100)))
+	d.UseNumber()
+
+	tok, err := d.Token()
+	require.NoError(t, err)
+	require.Equal(t, Number(strings.Repeat("9", 100)), tok)
+	requireEOF(t, d)
+}
+
+func TestNumberGrammar(t *testing.T) {
+	bad := []string{"4..", "00", "-", "1.e5", "1.", "-01", "1e", "1e+"}
+	for _, s := range bad {
+		d := NewDecoder(bytes.NewBufferString(s))
+		requireError(t, d)
+	}
+}
+
+func TestNumberGrammarOK(t *testing.T) {
+	d := NewDecoder(bytes.NewBufferString("0 -0 0.5 1e10 1E+10 1e-10 -123.456"))
+	requireToken(t, d, int64(0))
+	requireToken(t, d, int64(0))
+	requireToken(t, d, 0.5)
+	requireToken(t, d, 1e10)
+	requireToken(t, d, 1e10)
+	requireToken(t, d, 1e-10)
+	requireToken(t, d, -123.456)
+	requireEOF(t, d)
+}
+
+func TestUseNumberDecode(t *testing.T) {
+	type withNumbers struct {
+		X int         `json:"x"`
+		Y interface{} `json:"y"`
+		Z string      `json:"z"`
+	}
+
+	d := NewDecoder(bytes.NewBufferString(`{"x": 5, "y": 5, "z": 5}`))
+	d.UseNumber()
+
+	var w withNumbers
+	require.NoError(t, d.Decode(&w))
+	require.Equal(t, 5, w.X)
+	require.Equal(t, Number("5"), w.Y)
+	require.Equal(t, "5", w.Z)
+}
+
+func TestUseNumberEncodeRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	e := NewEncoder(&buf)
+	require.NoError(t, e.WriteNumber(Number("123456789012345678901234567890")))
+
+	d := NewDecoder(bytes.NewReader(buf.Bytes()))
+	d.UseNumber()
+	requireToken(t, d, Number("123456789012345678901234567890"))
+	requireEOF(t, d)
+}
@@ -0,0 +1,146 @@
+package jsonstream
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+)
+
+// Raw reads the next complete JSON value from the stream and returns the
+// exact bytes that made it up, whitespace-trimmed at the edges. Unlike
+// Token it does not interpret the value, so it can be used to defer
+// parsing of a sub-document (for example to decode it later with another
+// Decoder, or to forward it verbatim)
+func (d *Decoder) Raw() (json.RawMessage, error) {
+	first, err := d.skipWhitespace()
+	if err != nil {
+		return nil, err
+	}
+
+	buf := []byte{first}
+	if err := d.copyValue(first, &buf); err != nil {
+		return nil, err
+	}
+
+	return json.RawMessage(buf), nil
+}
+
+// Skip reads and discards the next complete JSON value from the stream,
+// without allocating a copy of its bytes
+func (d *Decoder) Skip() error {
+	first, err := d.skipWhitespace()
+	if err != nil {
+		return err
+	}
+
+	return d.copyValue(first, nil)
+}
+
+// copyValue consumes the remainder of the value that starts with first,
+// appending the bytes it reads to buf if buf is non-nil
+func (d *Decoder) copyValue(first byte, buf *[]byte) error {
+	switch {
+	case first == '{' || first == '[':
+		return d.copyContainer(buf)
+	case first == '"':
+		return d.copyString(buf)
+	default:
+		return d.copyLiteral(buf)
+	}
+}
+
+// copyContainer consumes bytes up to and including the delimiter that
+// closes the container opened by the delimiter already read, tracking
+// nesting depth. It does not itself distinguish '{'/'[' pairing, matching
+// the permissive style of the rest of the package
+func (d *Decoder) copyContainer(buf *[]byte) error {
+	depth := 1
+
+	for depth > 0 {
+		b, err := d.next()
+		if err == io.EOF {
+			return d.err(errors.New("unexpected EOF while skipping value"))
+		} else if err != nil {
+			return err
+		}
+
+		appendByte(buf, b)
+
+		switch b {
+		case '"':
+			if err := d.copyString(buf); err != nil {
+				return err
+			}
+		case '{', '[':
+			depth++
+		case '}', ']':
+			depth--
+		}
+	}
+
+	return nil
+}
+
+// copyString consumes bytes up to and including the closing quote of a
+// string value whose opening quote has already been read
+func (d *Decoder) copyString(buf *[]byte) error {
+	for {
+		b, err := d.next()
+		if err == io.EOF {
+			return d.err(errors.New("unexpected EOF while skipping string"))
+		} else if err != nil {
+			return err
+		}
+
+		appendByte(buf, b)
+
+		if b == '\\' {
+			escaped, err := d.next()
+			if err == io.EOF {
+				return d.err(errors.New("unexpected EOF while skipping string"))
+			} else if err != nil {
+				return err
+			}
+			appendByte(buf, escaped)
+			continue
+		}
+
+		if b == '"' {
+			return nil
+		}
+	}
+}
+
+// copyLiteral consumes bytes belonging to a number or a literal (true,
+// false, null) until a structural or whitespace byte is encountered
+func (d *Decoder) copyLiteral(buf *[]byte) error {
+	for {
+		b, err := d.next()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		if isTerminator(b) {
+			d.undo(b)
+			return nil
+		}
+
+		appendByte(buf, b)
+	}
+}
+
+func isTerminator(b byte) bool {
+	switch b {
+	case ' ', '\t', '\r', '\n', ',', ':', '{', '}', '[', ']':
+		return true
+	}
+	return false
+}
+
+func appendByte(buf *[]byte, b byte) {
+	if buf != nil {
+		*buf = append(*buf, b)
+	}
+}
@@ -0,0 +1,64 @@
+package jsonstream
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRaw(t *testing.T) {
+	d := NewDecoder(bytes.NewBufferString(`[1, {"a": [1,2,"}]"], "b": "x"}, true]`))
+	requireToken(t, d, Delim('['))
+	requireToken(t, d, int64(1))
+
+	raw, err := d.Raw()
+	require.NoError(t, err)
+	require.Equal(t, json.RawMessage(`{"a": [1,2,"}]"], "b": "x"}`), raw)
+
+	requireToken(t, d, true)
+	requireToken(t, d, Delim(']'))
+	requireEOF(t, d)
+}
+
+func TestRawScalar(t *testing.T) {
+	d := NewDecoder(bytes.NewBufferString(`  "foo\"bar"  42  false  null`))
+	raw, err := d.Raw()
+	require.NoError(t, err)
+	require.Equal(t, json.RawMessage(`"foo\"bar"`), raw)
+
+	raw, err = d.Raw()
+	require.NoError(t, err)
+	require.Equal(t, json.RawMessage(`42`), raw)
+
+	raw, err = d.Raw()
+	require.NoError(t, err)
+	require.Equal(t, json.RawMessage(`false`), raw)
+
+	raw, err = d.Raw()
+	require.NoError(t, err)
+	require.Equal(t, json.RawMessage(`null`), raw)
+}
+
+func TestRawTruncated(t *testing.T) {
+	d := NewDecoder(bytes.NewBufferString(`{"a": [1,2`))
+	_, err := d.Raw()
+	require.Error(t, err)
+
+	d = NewDecoder(bytes.NewBufferString(`"foo`))
+	_, err = d.Raw()
+	require.Error(t, err)
+}
+
+func TestSkip(t *testing.T) {
+	d := NewDecoder(bytes.NewBufferString(`[1, {"a": [1,2,"}]"], "b": "x"}, true]`))
+	requireToken(t, d, Delim('['))
+	requireToken(t, d, int64(1))
+
+	require.NoError(t, d.Skip())
+
+	requireToken(t, d, true)
+	requireToken(t, d, Delim(']'))
+	requireEOF(t, d)
+}
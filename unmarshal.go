@@ -0,0 +1,458 @@
+package jsonstream
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"reflect"
+	"strings"
+)
+
+// Unmarshal parses the JSON-encoded data and stores the result in the
+// value pointed to by v, following the same field-matching rules as
+// (*Decoder).Decode
+func Unmarshal(data []byte, v interface{}) error {
+	d := NewDecoder(bytes.NewReader(data))
+	err := d.Decode(v)
+	if err != nil {
+		if _, ok := err.(*UnmarshalTypeError); !ok {
+			return err
+		}
+	}
+
+	if _, tokErr := d.Token(); tokErr != io.EOF {
+		if tokErr == nil {
+			return errors.New("jsonstream: invalid character after top-level value")
+		}
+		return tokErr
+	}
+
+	return err
+}
+
+// Decode reads the next JSON value from the stream and stores it in the
+// value pointed to by v. It supports structs (matched on their `json`
+// tag or field name), maps, slices, pointers and the scalar types.
+// String values destined for a []byte or an io.Writer field are streamed
+// directly, without buffering the whole value as a Go string first.
+//
+// As with encoding/json, a field whose JSON value doesn't match its Go
+// type does not abort the decode: Decode keeps going so the caller gets
+// as much of the document as possible, and returns the first such
+// mismatch, wrapped as *UnmarshalTypeError, once the value has been fully
+// read. Malformed JSON or an I/O error from the underlying reader aborts
+// immediately
+func (d *Decoder) Decode(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return &InvalidUnmarshalError{Type: reflect.TypeOf(v)}
+	}
+
+	var typeErr error
+	if err := d.decodeValue(rv.Elem(), &typeErr); err != nil {
+		return err
+	}
+
+	return typeErr
+}
+
+// UnmarshalTypeError describes a JSON value that could not be stored in a
+// Go value of the given type
+type UnmarshalTypeError struct {
+	Value  string
+	Type   reflect.Type
+	Offset int64
+}
+
+func (e *UnmarshalTypeError) Error() string {
+	return fmt.Sprintf("jsonstream: cannot unmarshal %s into Go value of type %s (offset %d)",
+		e.Value, e.Type, e.Offset)
+}
+
+// InvalidUnmarshalError occurs when Decode or Unmarshal is passed a value
+// that is not a non-nil pointer
+type InvalidUnmarshalError struct {
+	Type reflect.Type
+}
+
+func (e *InvalidUnmarshalError) Error() string {
+	if e.Type == nil {
+		return "jsonstream: Decode(nil)"
+	}
+	if e.Type.Kind() != reflect.Ptr {
+		return fmt.Sprintf("jsonstream: Decode(non-pointer %s)", e.Type)
+	}
+	return fmt.Sprintf("jsonstream: Decode(nil %s)", e.Type)
+}
+
+func (d *Decoder) recordTypeError(typeErr *error, value string, t reflect.Type) {
+	if *typeErr == nil {
+		*typeErr = &UnmarshalTypeError{Value: value, Type: t, Offset: d.InputOffset()}
+	}
+}
+
+// decodeValue decodes the next JSON value into rv, which must be
+// addressable and settable
+func (d *Decoder) decodeValue(rv reflect.Value, typeErr *error) error {
+	first, err := d.skipWhitespace()
+	if err != nil {
+		return err
+	}
+	d.undo(first)
+
+	if first == 'n' {
+		if _, err := d.Token(); err != nil {
+			return err
+		}
+		switch rv.Kind() {
+		case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice:
+			rv.Set(reflect.Zero(rv.Type()))
+		}
+		return nil
+	}
+
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			rv.Set(reflect.New(rv.Type().Elem()))
+		}
+		rv = rv.Elem()
+	}
+
+	switch first {
+	case '{':
+		return d.decodeObject(rv, typeErr)
+	case '[':
+		return d.decodeArray(rv, typeErr)
+	case '"':
+		return d.decodeStringValue(rv, typeErr)
+	default:
+		return d.decodeLiteralValue(rv, typeErr)
+	}
+}
+
+func (d *Decoder) decodeStringValue(rv reflect.Value, typeErr *error) error {
+	switch {
+	case rv.Kind() == reflect.String:
+		s, err := d.readStringToken()
+		if err != nil {
+			return err
+		}
+		rv.SetString(s)
+		return nil
+	case rv.Kind() == reflect.Interface:
+		s, err := d.readStringToken()
+		if err != nil {
+			return err
+		}
+		rv.Set(reflect.ValueOf(s))
+		return nil
+	case rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() == reflect.Uint8:
+		r, err := d.StringReader()
+		if err != nil {
+			return err
+		}
+		b, err := ioutil.ReadAll(r)
+		if err != nil {
+			return err
+		}
+		rv.SetBytes(b)
+		return nil
+	}
+
+	if rv.CanAddr() {
+		if w, ok := rv.Addr().Interface().(io.Writer); ok {
+			r, err := d.StringReader()
+			if err != nil {
+				return err
+			}
+			_, err = io.Copy(w, r)
+			return err
+		}
+	}
+
+	s, err := d.readStringToken()
+	if err != nil {
+		return err
+	}
+	d.recordTypeError(typeErr, fmt.Sprintf("string %q", s), rv.Type())
+	return nil
+}
+
+func (d *Decoder) readStringToken() (string, error) {
+	tok, err := d.Token()
+	if err != nil {
+		return "", err
+	}
+	return tok.(string), nil
+}
+
+func (d *Decoder) decodeLiteralValue(rv reflect.Value, typeErr *error) error {
+	tok, err := d.Token()
+	if err != nil {
+		return err
+	}
+
+	switch t := tok.(type) {
+	case bool:
+		switch rv.Kind() {
+		case reflect.Bool:
+			rv.SetBool(t)
+		case reflect.Interface:
+			rv.Set(reflect.ValueOf(t))
+		default:
+			d.recordTypeError(typeErr, fmt.Sprintf("boolean %t", t), rv.Type())
+		}
+	case int64, float64, Number:
+		d.assignNumber(rv, t, typeErr)
+	default:
+		d.recordTypeError(typeErr, fmt.Sprintf("value %v", t), rv.Type())
+	}
+
+	return nil
+}
+
+func (d *Decoder) assignNumber(rv reflect.Value, tok interface{}, typeErr *error) {
+	switch rv.Kind() {
+	case reflect.Interface:
+		rv.Set(reflect.ValueOf(tok))
+	case reflect.String:
+		if n, ok := tok.(Number); ok {
+			rv.SetString(n.String())
+		} else {
+			d.recordTypeError(typeErr, fmt.Sprintf("number %v", tok), rv.Type())
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, ok := toInt64(tok)
+		if !ok || overflowsInt(i, rv.Type().Bits()) {
+			d.recordTypeError(typeErr, fmt.Sprintf("number %v", tok), rv.Type())
+			return
+		}
+		rv.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		i, ok := toInt64(tok)
+		if !ok || i < 0 || overflowsUint(uint64(i), rv.Type().Bits()) {
+			d.recordTypeError(typeErr, fmt.Sprintf("number %v", tok), rv.Type())
+			return
+		}
+		rv.SetUint(uint64(i))
+	case reflect.Float32, reflect.Float64:
+		rv.SetFloat(toFloat64(tok))
+	default:
+		d.recordTypeError(typeErr, fmt.Sprintf("number %v", tok), rv.Type())
+	}
+}
+
+// toInt64 converts a Token numeric value (int64, float64 or, with
+// UseNumber, Number) to an int64, rejecting values that aren't exactly
+// representable as one (e.g. a float with a fractional part)
+func toInt64(tok interface{}) (int64, bool) {
+	switch t := tok.(type) {
+	case int64:
+		return t, true
+	case float64:
+		if t != math.Trunc(t) || t < math.MinInt64 || t >= math.MaxInt64 {
+			return 0, false
+		}
+		return int64(t), true
+	case Number:
+		if i, err := t.Int64(); err == nil {
+			return i, true
+		}
+		if f, err := t.Float64(); err == nil && f == math.Trunc(f) &&
+			f >= math.MinInt64 && f < math.MaxInt64 {
+			return int64(f), true
+		}
+	}
+	return 0, false
+}
+
+// overflowsInt reports whether i does not fit in a signed integer of the
+// given bit width (64 means no limit, matching reflect.Type.Bits for int/int64)
+func overflowsInt(i int64, bits int) bool {
+	if bits >= 64 {
+		return false
+	}
+	limit := int64(1) << (bits - 1)
+	return i < -limit || i >= limit
+}
+
+// overflowsUint reports whether i does not fit in an unsigned integer of
+// the given bit width (64 means no limit, matching reflect.Type.Bits for uint/uint64)
+func overflowsUint(i uint64, bits int) bool {
+	if bits >= 64 {
+		return false
+	}
+	return i >= uint64(1)<<bits
+}
+
+// toFloat64 converts a Token numeric value (int64, float64 or, with
+// UseNumber, Number) to a float64
+func toFloat64(tok interface{}) float64 {
+	switch t := tok.(type) {
+	case int64:
+		return float64(t)
+	case float64:
+		return t
+	case Number:
+		f, _ := t.Float64()
+		return f
+	}
+	return 0
+}
+
+func (d *Decoder) decodeArray(rv reflect.Value, typeErr *error) error {
+	it, err := d.ArrIter()
+	if err != nil {
+		return err
+	}
+
+	switch rv.Kind() {
+	case reflect.Slice:
+		rv.Set(reflect.MakeSlice(rv.Type(), 0, 0))
+		for it.Next() {
+			elem := reflect.New(rv.Type().Elem()).Elem()
+			if err := d.decodeValue(elem, typeErr); err != nil {
+				return err
+			}
+			rv.Set(reflect.Append(rv, elem))
+		}
+		return it.Err()
+	case reflect.Interface:
+		var result []interface{}
+		for it.Next() {
+			var elem interface{}
+			if err := d.decodeValue(reflect.ValueOf(&elem).Elem(), typeErr); err != nil {
+				return err
+			}
+			result = append(result, elem)
+		}
+		if err := it.Err(); err != nil {
+			return err
+		}
+		rv.Set(reflect.ValueOf(result))
+		return nil
+	default:
+		for it.Next() {
+			if err := d.Skip(); err != nil {
+				return err
+			}
+		}
+		if err := it.Err(); err != nil {
+			return err
+		}
+		d.recordTypeError(typeErr, "array", rv.Type())
+		return nil
+	}
+}
+
+func (d *Decoder) decodeObject(rv reflect.Value, typeErr *error) error {
+	it, err := d.ObjIter()
+	if err != nil {
+		return err
+	}
+
+	switch rv.Kind() {
+	case reflect.Struct:
+		for it.Next() {
+			key := it.Key()
+			field, ok := findField(rv.Type(), key)
+			if !ok {
+				if err := d.Skip(); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := d.decodeValue(rv.FieldByIndex(field.Index), typeErr); err != nil {
+				return err
+			}
+		}
+		return it.Err()
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			for it.Next() {
+				if err := d.Skip(); err != nil {
+					return err
+				}
+			}
+			if err := it.Err(); err != nil {
+				return err
+			}
+			d.recordTypeError(typeErr, "object", rv.Type())
+			return nil
+		}
+
+		if rv.IsNil() {
+			rv.Set(reflect.MakeMap(rv.Type()))
+		}
+		for it.Next() {
+			key := reflect.ValueOf(it.Key()).Convert(rv.Type().Key())
+			elem := reflect.New(rv.Type().Elem()).Elem()
+			if err := d.decodeValue(elem, typeErr); err != nil {
+				return err
+			}
+			rv.SetMapIndex(key, elem)
+		}
+		return it.Err()
+	case reflect.Interface:
+		result := map[string]interface{}{}
+		for it.Next() {
+			key := it.Key()
+			var val interface{}
+			if err := d.decodeValue(reflect.ValueOf(&val).Elem(), typeErr); err != nil {
+				return err
+			}
+			result[key] = val
+		}
+		if err := it.Err(); err != nil {
+			return err
+		}
+		rv.Set(reflect.ValueOf(result))
+		return nil
+	default:
+		for it.Next() {
+			if err := d.Skip(); err != nil {
+				return err
+			}
+		}
+		if err := it.Err(); err != nil {
+			return err
+		}
+		d.recordTypeError(typeErr, "object", rv.Type())
+		return nil
+	}
+}
+
+// findField locates the struct field matching the JSON key key, honoring
+// `json:"name"` tags (including "-" to skip a field) and falling back to
+// a case-insensitive match on the Go field name
+func findField(t reflect.Type, key string) (reflect.StructField, bool) {
+	var fallback reflect.StructField
+	haveFallback := false
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		if name == key {
+			return field, true
+		}
+		if !haveFallback && strings.EqualFold(name, key) {
+			fallback, haveFallback = field, true
+		}
+	}
+
+	return fallback, haveFallback
+}
@@ -0,0 +1,167 @@
+package jsonstream
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type address struct {
+	City string `json:"city"`
+	Zip  string `json:"zip"`
+}
+
+type person struct {
+	Name      string   `json:"name"`
+	Age       int      `json:"age"`
+	Pi        float64  `json:"pi"`
+	Tags      []string `json:"tags"`
+	Addr      address  `json:"addr"`
+	Ignored   string   `json:"-"`
+	Unset     bool
+	Bio       bytes.Buffer `json:"bio"`
+	lowercase string
+}
+
+func TestUnmarshalStruct(t *testing.T) {
+	var p person
+	err := Unmarshal([]byte(`{
+		"name": "Ada",
+		"age": 36,
+		"pi": 3.5,
+		"tags": ["x", "y"],
+		"addr": {"city": "London", "zip": "E1"},
+		"-": "nope",
+		"bio": "streamed in",
+		"unknown": 123
+	}`), &p)
+	require.NoError(t, err)
+	require.Equal(t, "Ada", p.Name)
+	require.Equal(t, 36, p.Age)
+	require.Equal(t, 3.5, p.Pi)
+	require.Equal(t, []string{"x", "y"}, p.Tags)
+	require.Equal(t, address{City: "London", Zip: "E1"}, p.Addr)
+	require.Equal(t, "", p.Ignored)
+	require.Equal(t, "streamed in", p.Bio.String())
+}
+
+func TestUnmarshalMap(t *testing.T) {
+	var m map[string]int
+	err := Unmarshal([]byte(`{"a": 1, "b": 2}`), &m)
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{"a": 1, "b": 2}, m)
+}
+
+func TestUnmarshalSlice(t *testing.T) {
+	var s []int
+	err := Unmarshal([]byte(`[1,2,3]`), &s)
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 2, 3}, s)
+}
+
+func TestUnmarshalPointerField(t *testing.T) {
+	type withPtr struct {
+		Addr *address `json:"addr"`
+	}
+
+	var w withPtr
+	err := Unmarshal([]byte(`{"addr": {"city": "Paris", "zip": "75"}}`), &w)
+	require.NoError(t, err)
+	require.Equal(t, &address{City: "Paris", Zip: "75"}, w.Addr)
+
+	w = withPtr{}
+	err = Unmarshal([]byte(`{"addr": null}`), &w)
+	require.NoError(t, err)
+	require.Nil(t, w.Addr)
+}
+
+func TestUnmarshalInterface(t *testing.T) {
+	var v interface{}
+	err := Unmarshal([]byte(`{"a": [1, "x", true, null]}`), &v)
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{
+		"a": []interface{}{int64(1), "x", true, nil},
+	}, v)
+}
+
+func TestUnmarshalBytes(t *testing.T) {
+	var b []byte
+	err := Unmarshal([]byte(`"hello"`), &b)
+	require.NoError(t, err)
+	require.Equal(t, []byte("hello"), b)
+}
+
+func TestUnmarshalTypeError(t *testing.T) {
+	type withInt struct {
+		A int    `json:"a"`
+		B int    `json:"b"`
+		C string `json:"c"`
+	}
+
+	var w withInt
+	err := Unmarshal([]byte(`{"a": "oops", "b": 2, "c": "fine"}`), &w)
+	require.Error(t, err)
+
+	typeErr, ok := err.(*UnmarshalTypeError)
+	require.True(t, ok)
+	require.Equal(t, reflect.TypeOf(0), typeErr.Type)
+
+	// the decode should have continued past the mismatched field
+	require.Equal(t, 2, w.B)
+	require.Equal(t, "fine", w.C)
+}
+
+func TestUnmarshalFractionalIntoInt(t *testing.T) {
+	var w struct {
+		X int `json:"x"`
+	}
+	err := Unmarshal([]byte(`{"x": 5.7}`), &w)
+	require.Error(t, err)
+
+	_, ok := err.(*UnmarshalTypeError)
+	require.True(t, ok)
+	require.Equal(t, 0, w.X)
+}
+
+func TestUnmarshalIntOverflow(t *testing.T) {
+	var w struct {
+		X int8  `json:"x"`
+		Y uint8 `json:"y"`
+	}
+	err := Unmarshal([]byte(`{"x": 1000, "y": 999}`), &w)
+	require.Error(t, err)
+
+	_, ok := err.(*UnmarshalTypeError)
+	require.True(t, ok)
+	require.Equal(t, int8(0), w.X)
+	require.Equal(t, uint8(0), w.Y)
+}
+
+func TestUnmarshalNegativeIntoUint(t *testing.T) {
+	var w struct {
+		X uint `json:"x"`
+	}
+	err := Unmarshal([]byte(`{"x": -1}`), &w)
+	require.Error(t, err)
+
+	_, ok := err.(*UnmarshalTypeError)
+	require.True(t, ok)
+	require.Equal(t, uint(0), w.X)
+}
+
+func TestUnmarshalInvalidTarget(t *testing.T) {
+	var i int
+	err := Unmarshal([]byte(`1`), i)
+	require.Error(t, err)
+
+	_, ok := err.(*InvalidUnmarshalError)
+	require.True(t, ok)
+}
+
+func TestUnmarshalTrailingData(t *testing.T) {
+	var i int
+	err := Unmarshal([]byte(`1 2`), &i)
+	require.Error(t, err)
+}